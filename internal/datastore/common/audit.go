@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"time"
+)
+
+// AuditOperation identifies the datastore operation an AuditRecord describes.
+type AuditOperation string
+
+const (
+	AuditOperationQueryRelationships        AuditOperation = "query_relationships"
+	AuditOperationReverseQueryRelationships AuditOperation = "reverse_query_relationships"
+	AuditOperationReadNamespaceByName       AuditOperation = "read_namespace_by_name"
+	AuditOperationWrite                     AuditOperation = "write"
+)
+
+// AuditRecord describes a single datastore operation for the purposes of an
+// AuditSink. For read operations, SQL is the fully inlined statement (see
+// InlineSqlArgs), making a record self-contained and safe to replay against
+// a SQL console without needing to reconstruct bind arguments. For
+// AuditOperationWrite records, SQL is empty.
+type AuditRecord struct {
+	Operation AuditOperation
+	Caller    string
+	SQL       string
+	Revision  string
+	Err       error
+	Timestamp time.Time
+}
+
+// AuditSink receives an AuditRecord for every datastore operation that
+// shouldAudit, if set, did not filter out.
+//
+// Implementations are invoked from mysqlReader's query methods, which pass
+// the already-resolved SQL (via InlineSqlArgs) rather than the raw
+// query/args pair, so a sink never needs a statement cache to make sense of
+// a read record on its own. On the write path they are invoked once per
+// committed transaction, with no SQL: the statements the transaction ran
+// are not recorded.
+type AuditSink interface {
+	Audit(ctx context.Context, record AuditRecord)
+}
+
+// ShouldAuditFunc lets operators filter which operations reach an AuditSink,
+// e.g. to drop high-volume read paths that would otherwise dominate the
+// audit trail.
+type ShouldAuditFunc func(record AuditRecord) bool
+
+// AuditAll is the default ShouldAuditFunc: every record is audited.
+func AuditAll(AuditRecord) bool { return true }
+
+// callerKey is the context key under which the caller identity used in
+// AuditRecord.Caller is stored.
+type callerKey struct{}
+
+// ContextWithAuditCaller returns a context carrying caller as the identity
+// that will be attached to any AuditRecord emitted while it is in scope.
+func ContextWithAuditCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// AuditCallerFromContext returns the caller identity stored by
+// ContextWithAuditCaller, or "" if none was set.
+func AuditCallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
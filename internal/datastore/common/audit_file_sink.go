@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FileAuditSink writes one JSON object per line, one line per AuditRecord.
+// It is safe for concurrent use.
+type FileAuditSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	should ShouldAuditFunc
+}
+
+// jsonAuditRecord is the on-disk shape written by FileAuditSink. AuditRecord
+// isn't marshaled directly because its Err field isn't JSON-serializable.
+type jsonAuditRecord struct {
+	Operation AuditOperation `json:"operation"`
+	Caller    string         `json:"caller"`
+	SQL       string         `json:"sql"`
+	Revision  string         `json:"revision"`
+	Error     string         `json:"error,omitempty"`
+	Timestamp string         `json:"timestamp"`
+}
+
+// NewFileAuditSink returns an AuditSink that appends newline-delimited JSON
+// records to w. If should is nil, every record is audited.
+func NewFileAuditSink(w io.Writer, should ShouldAuditFunc) *FileAuditSink {
+	if should == nil {
+		should = AuditAll
+	}
+	return &FileAuditSink{w: w, should: should}
+}
+
+// Audit implements AuditSink.
+func (s *FileAuditSink) Audit(ctx context.Context, record AuditRecord) {
+	if !s.should(record) {
+		return
+	}
+
+	errStr := ""
+	if record.Err != nil {
+		errStr = record.Err.Error()
+	}
+
+	line, err := json.Marshal(jsonAuditRecord{
+		Operation: record.Operation,
+		Caller:    record.Caller,
+		SQL:       record.SQL,
+		Revision:  record.Revision,
+		Error:     errStr,
+		Timestamp: record.Timestamp.Format("2006-01-02T15:04:05.000000000Z07:00"),
+	})
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to marshal audit record")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.w, "%s\n", line); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write audit record")
+	}
+}
+
+var _ AuditSink = &FileAuditSink{}
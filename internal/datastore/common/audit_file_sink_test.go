@@ -0,0 +1,70 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileAuditSinkWritesOneJSONLinePerRecord(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewFileAuditSink(&buf, nil)
+
+	sink.Audit(context.Background(), AuditRecord{
+		Operation: AuditOperationQueryRelationships,
+		Caller:    "service-account:checker",
+		SQL:       "SELECT * FROM relationship_tuple",
+		Revision:  "123",
+		Timestamp: time.Unix(0, 0).UTC(),
+	})
+	sink.Audit(context.Background(), AuditRecord{
+		Operation: AuditOperationWrite,
+		Caller:    "service-account:writer",
+		Err:       errors.New("boom"),
+		Timestamp: time.Unix(1, 0).UTC(),
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first jsonAuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.Equal(t, AuditOperationQueryRelationships, first.Operation)
+	require.Equal(t, "service-account:checker", first.Caller)
+	require.Empty(t, first.Error)
+
+	var second jsonAuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	require.Equal(t, AuditOperationWrite, second.Operation)
+	require.Equal(t, "boom", second.Error)
+}
+
+func TestFileAuditSinkHonorsShouldAudit(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewFileAuditSink(&buf, func(record AuditRecord) bool {
+		return record.Operation != AuditOperationQueryRelationships
+	})
+
+	sink.Audit(context.Background(), AuditRecord{Operation: AuditOperationQueryRelationships})
+	sink.Audit(context.Background(), AuditRecord{Operation: AuditOperationWrite})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var record jsonAuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, AuditOperationWrite, record.Operation)
+}
+
+func TestContextAuditCallerRoundTrips(t *testing.T) {
+	require.Equal(t, "", AuditCallerFromContext(context.Background()))
+
+	ctx := ContextWithAuditCaller(context.Background(), "user:emilia")
+	require.Equal(t, "user:emilia", AuditCallerFromContext(ctx))
+}
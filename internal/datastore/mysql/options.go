@@ -0,0 +1,107 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+)
+
+// Option configures optional behavior of a mysqlReader, such as chunked
+// relationship history and write/read auditing.
+type Option func(*mysqlOptions)
+
+type mysqlOptions struct {
+	chunking  *PartitionConfig
+	auditSink common.AuditSink
+}
+
+// defaultChunkConfig is used whenever ChunkInterval or ChunkRetention is set
+// without the other; it matches the "start simple" defaults documented for
+// the feature: daily chunks, checked for archiving/pruning every minute.
+func defaultChunkConfig() PartitionConfig {
+	return PartitionConfig{
+		ChunkInterval: 24 * time.Hour,
+		PruneInterval: time.Minute,
+	}
+}
+
+func (o *mysqlOptions) chunkConfig() *PartitionConfig {
+	if o.chunking == nil {
+		cfg := defaultChunkConfig()
+		o.chunking = &cfg
+	}
+	return o.chunking
+}
+
+// ChunkInterval enables chunked relationship history, creating, backfilling,
+// and eventually pruning one chunk table per interval instead of storing all
+// history in a single ever-growing relationship_tuple table.
+func ChunkInterval(interval time.Duration) Option {
+	return func(o *mysqlOptions) {
+		o.chunkConfig().ChunkInterval = interval
+	}
+}
+
+// ChunkRetention overrides how long a chunk is kept after its interval
+// closes. When unset, retention is derived from the datastore's configured
+// GC window instead.
+func ChunkRetention(retention time.Duration) Option {
+	return func(o *mysqlOptions) {
+		o.chunkConfig().RetentionNanos = retention.Nanoseconds()
+	}
+}
+
+// ChunkPruneInterval overrides how often the background goroutine checks
+// for intervals to archive and chunks that have aged out. Defaults to one
+// minute.
+func ChunkPruneInterval(interval time.Duration) Option {
+	return func(o *mysqlOptions) {
+		o.chunkConfig().PruneInterval = interval
+	}
+}
+
+// WithAuditSink routes this reader's QueryRelationships,
+// ReverseQueryRelationships and ReadNamespaceByName calls, and the commit of
+// every write transaction built from it (see newMySQLReadWriteTransaction),
+// through sink.
+func WithAuditSink(sink common.AuditSink) Option {
+	return func(o *mysqlOptions) {
+		o.auditSink = sink
+	}
+}
+
+// newMySQLReader builds a mysqlReader for the given revision, applying opts
+// uniformly regardless of caller. When chunking is enabled, this also starts
+// the reader's PartitionManager; callers that want to stop it (e.g. on
+// datastore Close) can reach it via the returned reader's partitions field.
+func newMySQLReader(
+	ctx context.Context,
+	atRevisionNanos int64,
+	txSource txFactory,
+	executor common.QueryExecutor,
+	filterer queryFilterer,
+	ddl ddlExecutor,
+	gcWindowNanos func() int64,
+	opts ...Option,
+) *mysqlReader {
+	var parsed mysqlOptions
+	for _, opt := range opts {
+		opt(&parsed)
+	}
+
+	mr := &mysqlReader{
+		txSource:        txSource,
+		executor:        executor,
+		filterer:        filterer,
+		atRevisionNanos: atRevisionNanos,
+		auditSink:       parsed.auditSink,
+	}
+
+	if parsed.chunking != nil {
+		mr.partitions = NewPartitionManager(ddl, *parsed.chunking, gcWindowNanos)
+		mr.partitions.Start(ctx)
+	}
+
+	return mr
+}
@@ -0,0 +1,404 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/rs/zerolog/log"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+)
+
+// ddlExecutor is the subset of *sql.DB the PartitionManager needs to create,
+// inspect, and drop chunk tables. It's narrower than common.QueryExecutor,
+// which is built for running filtered relationship queries rather than raw
+// DDL/DML.
+type ddlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// relationshipTupleChunkTable is the bookkeeping table the PartitionManager
+// uses to track which chunk tables exist for a given parent and whether
+// they've been backfilled. RouteToChunks only ever reads through a chunk
+// once its row here is marked backfilled, so a chunk that exists but hasn't
+// finished copying rows out of the parent table is never substituted for
+// live data.
+const relationshipTupleChunkTable = "relationship_tuple_chunk"
+
+// chunkBootstrapDDL creates the relationshipTupleChunkTable bookkeeping
+// table. This is the one migration step required to adopt chunking:
+// relationship_tuple and relationship_tuple_transaction keep their existing
+// rows in place. Each pass of archiveElapsedIntervals backfills only the
+// most recently closed interval, so existing rows from intervals that had
+// already closed before that are never moved into a chunk and stay in the
+// parent table.
+const chunkBootstrapDDL = "" +
+	"CREATE TABLE IF NOT EXISTS `" + relationshipTupleChunkTable + "` (" +
+	"  id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY," +
+	"  parent_table VARCHAR(128) NOT NULL," +
+	"  chunk_table VARCHAR(128) NOT NULL," +
+	"  interval_start_nanos BIGINT NOT NULL," +
+	"  backfilled BOOLEAN NOT NULL DEFAULT FALSE," +
+	"  UNIQUE KEY uq_relationship_tuple_chunk (parent_table, chunk_table)" +
+	")"
+
+// chunkTableNameLayout is the time layout used to derive a chunk's table
+// suffix from the start of its interval. Colons and spaces aren't valid in
+// MySQL identifiers, so the layout is restricted to digits.
+const chunkTableNameLayout = "20060102"
+
+// PartitionConfig controls how relationship history chunks are created and
+// retired by a PartitionManager.
+type PartitionConfig struct {
+	// ChunkInterval is the span of time covered by a single chunk table,
+	// e.g. 24h for daily chunks.
+	ChunkInterval time.Duration
+
+	// RetentionNanos is how long a chunk is kept around after its interval
+	// has closed before it becomes eligible for pruning. When zero, the
+	// retention is derived from RemoteClockRevisions.GCWindowNanos at
+	// prune time instead.
+	RetentionNanos int64
+
+	// PruneInterval is how often the background goroutine checks for
+	// intervals to archive and chunks that have aged out.
+	PruneInterval time.Duration
+}
+
+// PartitionManager owns the lifecycle of the per-interval chunk tables that
+// back `relationship_tuple` and `relationship_tuple_transaction`. Rather than
+// a single monotonically growing table pruned with `DELETE ... WHERE`,
+// history is spread across chunk tables keyed by transaction timestamp so
+// that GC can drop an entire table in O(1).
+//
+// relationship_tuple is additionally read through RouteToChunks, so its
+// chunks are backfilled from, and then trimmed out of, the parent table on
+// the first pass after their interval closes: the parent table holds the
+// still-open interval's rows plus any rows not yet archived (including rows
+// from intervals that closed before the manager's first pass), and
+// RouteToChunks always includes it alongside whichever closed chunks overlap
+// the query. Writes continue to land in the parent table unmodified.
+//
+// relationship_tuple_transaction chunking exists purely for GC: transaction
+// rows are looked up by transaction ID rather than scanned by time window,
+// so nothing needs to route reads through its chunks.
+type PartitionManager struct {
+	db     ddlExecutor
+	config PartitionConfig
+
+	gcWindowNanos func() int64
+
+	startOnce sync.Once
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewPartitionManager creates a PartitionManager. The manager does not
+// create, backfill, or prune any chunks until Start is called.
+func NewPartitionManager(db ddlExecutor, config PartitionConfig, gcWindowNanos func() int64) *PartitionManager {
+	return &PartitionManager{
+		db:            db,
+		config:        config,
+		gcWindowNanos: gcWindowNanos,
+		done:          make(chan struct{}),
+	}
+}
+
+// Start launches the background goroutine that archives elapsed intervals
+// and prunes expired chunks on config.PruneInterval. Start is a no-op if the
+// manager has already been started.
+func (pm *PartitionManager) Start(ctx context.Context) {
+	pm.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(ctx)
+		pm.cancel = cancel
+
+		go func() {
+			defer close(pm.done)
+
+			ticker := time.NewTicker(pm.config.PruneInterval)
+			defer ticker.Stop()
+
+			for {
+				if err := pm.archiveElapsedIntervals(ctx); err != nil {
+					log.Ctx(ctx).Err(err).Msg("failed to archive elapsed relationship history interval")
+				}
+				if err := pm.pruneExpiredChunks(ctx); err != nil {
+					log.Ctx(ctx).Err(err).Msg("failed to prune expired relationship history chunks")
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	})
+}
+
+// Stop halts the background goroutine and waits for it to exit.
+func (pm *PartitionManager) Stop() {
+	if pm.cancel == nil {
+		return
+	}
+	pm.cancel()
+	<-pm.done
+}
+
+// Bootstrap creates the relationship_tuple_chunk bookkeeping table. It must
+// be run once, before the first call to Start, when adopting chunking on an
+// existing deployment.
+func (pm *PartitionManager) Bootstrap(ctx context.Context) error {
+	_, err := pm.db.ExecContext(ctx, chunkBootstrapDDL)
+	return err
+}
+
+// chunkSuffix returns the table suffix for the chunk covering t.
+func (pm *PartitionManager) chunkSuffix(t time.Time) string {
+	intervalStart := t.Truncate(pm.config.ChunkInterval)
+	return intervalStart.UTC().Format(chunkTableNameLayout)
+}
+
+// chunkTableName returns the fully qualified child table name for the chunk
+// covering t, derived from the given parent table.
+func (pm *PartitionManager) chunkTableName(parent string, t time.Time) string {
+	return fmt.Sprintf("%s_%s", parent, pm.chunkSuffix(t))
+}
+
+func (pm *PartitionManager) retentionNanos() int64 {
+	if pm.config.RetentionNanos > 0 {
+		return pm.config.RetentionNanos
+	}
+	return pm.gcWindowNanos()
+}
+
+// currentIntervalStart returns the start of the still-open interval as of
+// now: the boundary below which rows have either already been archived into
+// a chunk, or are eligible to be on the next tick.
+func (pm *PartitionManager) currentIntervalStart(now time.Time) time.Time {
+	return now.Truncate(pm.config.ChunkInterval)
+}
+
+// archiveElapsedIntervals backfills the most recently closed interval for
+// both partitioned tables into a chunk table, then deletes the now-archived
+// rows out of the parent table, bounded to exactly that interval. Because
+// this runs once per closed interval rather than as a single sweep over the
+// whole retention window, both the backfill and the delete touch a small,
+// fixed-size slice of the table regardless of how much history has
+// accumulated.
+func (pm *PartitionManager) archiveElapsedIntervals(ctx context.Context) error {
+	closedIntervalStart := pm.currentIntervalStart(time.Now()).Add(-pm.config.ChunkInterval)
+
+	for _, parent := range []string{tableTuple, tableTransaction} {
+		archived, err := pm.isArchived(ctx, parent, closedIntervalStart)
+		if err != nil {
+			return fmt.Errorf("unable to check archive state for %s: %w", parent, err)
+		}
+		if archived {
+			continue
+		}
+
+		if err := pm.archiveInterval(ctx, parent, closedIntervalStart); err != nil {
+			return fmt.Errorf("unable to archive interval for %s: %w", parent, err)
+		}
+	}
+	return nil
+}
+
+// archiveInterval creates the chunk table for parent covering intervalStart,
+// copies the interval's rows into it from parent, deletes those same rows
+// back out of parent, and marks the chunk backfilled. Only relationship
+// rows are trimmed from their parent after archiving; transaction rows are
+// chunked for GC purposes only, so their parent copy is left untouched here.
+// pruneExpiredChunks later drops the chunk table; it does not delete the
+// parent's copy of those transaction rows.
+func (pm *PartitionManager) archiveInterval(ctx context.Context, parent string, intervalStart time.Time) error {
+	child := pm.chunkTableName(parent, intervalStart)
+	intervalEnd := intervalStart.Add(pm.config.ChunkInterval)
+
+	if _, err := pm.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` LIKE `%s`", child, parent)); err != nil {
+		return fmt.Errorf("unable to create chunk table: %w", err)
+	}
+
+	backfill, args, err := sq.Select("*").From(fmt.Sprintf("`%s`", parent)).
+		Where(sq.GtOrEq{colTimestamp: intervalStart.UnixNano()}).
+		Where(sq.Lt{colTimestamp: intervalEnd.UnixNano()}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO `%s` %s", child, backfill)
+	if _, err := pm.db.ExecContext(ctx, insert, args...); err != nil {
+		return fmt.Errorf("unable to backfill chunk table: %w", err)
+	}
+
+	if parent == tableTuple {
+		del, args, err := sq.Delete(fmt.Sprintf("`%s`", parent)).
+			Where(sq.GtOrEq{colTimestamp: intervalStart.UnixNano()}).
+			Where(sq.Lt{colTimestamp: intervalEnd.UnixNano()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := pm.db.ExecContext(ctx, del, args...); err != nil {
+			return fmt.Errorf("unable to trim archived rows from parent: %w", err)
+		}
+	}
+
+	upsert, args, err := sq.Insert(relationshipTupleChunkTable).
+		Columns("parent_table", "chunk_table", "interval_start_nanos", "backfilled").
+		Values(parent, child, intervalStart.UnixNano(), true).
+		Suffix("ON DUPLICATE KEY UPDATE backfilled = VALUES(backfilled)").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = pm.db.ExecContext(ctx, upsert, args...)
+	return err
+}
+
+// isArchived reports whether the chunk covering intervalStart for parent has
+// already been created and backfilled.
+func (pm *PartitionManager) isArchived(ctx context.Context, parent string, intervalStart time.Time) (bool, error) {
+	query, args, err := sq.Select("1").From(relationshipTupleChunkTable).
+		Where(sq.Eq{
+			"parent_table":         parent,
+			"interval_start_nanos": intervalStart.UnixNano(),
+			"backfilled":           true,
+		}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := pm.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	return rows.Next(), rows.Err()
+}
+
+// pruneExpiredChunks drops backfilled chunk tables whose entire interval has
+// fallen outside of the retention window, giving O(1) GC instead of
+// row-by-row deletes.
+func (pm *PartitionManager) pruneExpiredChunks(ctx context.Context) error {
+	cutoff := time.Now().Add(-time.Duration(pm.retentionNanos()) * time.Nanosecond)
+
+	// A chunk's interval closes ChunkInterval after it starts, so only chunks
+	// starting before cutoff-ChunkInterval closed before the cutoff.
+	closedBefore := cutoff.Add(-pm.config.ChunkInterval)
+
+	for _, parent := range []string{tableTuple, tableTransaction} {
+		chunks, err := pm.backfilledChunks(ctx, parent, 0, closedBefore.UnixNano())
+		if err != nil {
+			return fmt.Errorf("unable to list chunks for %s: %w", parent, err)
+		}
+
+		for _, chunk := range chunks {
+			if _, err := pm.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS `%s`", chunk.name)); err != nil {
+				return fmt.Errorf("unable to drop expired chunk %s: %w", chunk.name, err)
+			}
+
+			del, args, derr := sq.Delete(relationshipTupleChunkTable).
+				Where(sq.Eq{"parent_table": parent, "chunk_table": chunk.name}).
+				ToSql()
+			if derr != nil {
+				return derr
+			}
+			if _, err := pm.db.ExecContext(ctx, del, args...); err != nil {
+				return fmt.Errorf("unable to remove bookkeeping row for %s: %w", chunk.name, err)
+			}
+
+			log.Ctx(ctx).Debug().Str("chunk", chunk.name).Msg("dropped expired relationship history chunk")
+		}
+	}
+	return nil
+}
+
+type chunkDescriptor struct {
+	name          string
+	intervalStart int64
+}
+
+// backfilledChunks returns the backfilled chunks for parent whose interval
+// starts in [fromNanos, toNanos), read from relationship_tuple_chunk rather
+// than scanned out of information_schema on every call.
+func (pm *PartitionManager) backfilledChunks(ctx context.Context, parent string, fromNanos, toNanos int64) ([]chunkDescriptor, error) {
+	query, args, err := sq.Select("chunk_table", "interval_start_nanos").
+		From(relationshipTupleChunkTable).
+		Where(sq.Eq{"parent_table": parent, "backfilled": true}).
+		Where(sq.GtOrEq{"interval_start_nanos": fromNanos}).
+		Where(sq.Lt{"interval_start_nanos": toNanos}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pm.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var chunks []chunkDescriptor
+	for rows.Next() {
+		var chunk chunkDescriptor
+		if err := rows.Scan(&chunk.name, &chunk.intervalStart); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, rows.Err()
+}
+
+// RouteToChunks rewrites base so that it reads from the backfilled
+// relationship_tuple chunks overlapping revisionNanos, unioned with the
+// parent table, instead of scanning the parent table's full history.
+//
+// windowEnd is deliberately the start of the interval *after* the one
+// containing revisionNanos, not revisionNanos' own interval start: a
+// snapshot read at an older revision whose interval has since been archived
+// by archiveInterval needs that chunk included, since its rows are no
+// longer in the parent table. Only intervals strictly after the one
+// containing revisionNanos can be assumed to still be live in the parent.
+//
+// The chunk set is bounded on both ends by revisionNanos: only chunks whose
+// interval could contain a row visible as of that revision are included, so
+// an old revision scans fewer tables, not more. The parent table is always
+// included because it holds every row that archiving has not yet moved into
+// a chunk (see archiveInterval), which guarantees reads never miss rows that
+// haven't closed out into a chunk yet.
+func (pm *PartitionManager) RouteToChunks(ctx context.Context, base sq.SelectBuilder, revisionNanos int64) sq.SelectBuilder {
+	windowStart := revisionNanos - pm.retentionNanos()
+	windowEnd := pm.currentIntervalStart(time.Unix(0, revisionNanos)).Add(pm.config.ChunkInterval).UnixNano()
+
+	chunks, err := pm.backfilledChunks(ctx, tableTuple, windowStart, windowEnd)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to resolve relationship history chunks for query, falling back to parent table")
+		return base
+	}
+	if len(chunks) == 0 {
+		return base
+	}
+
+	union := []string{fmt.Sprintf("SELECT * FROM `%s`", tableTuple)}
+	for _, chunk := range chunks {
+		union = append(union, fmt.Sprintf("SELECT * FROM `%s`", chunk.name))
+	}
+	return base.From(fmt.Sprintf("(%s) AS `%s`", joinUnionAll(union), tableTuple))
+}
+
+func joinUnionAll(selects []string) string {
+	out := selects[0]
+	for _, s := range selects[1:] {
+		out += " UNION ALL " + s
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkTableName(t *testing.T) {
+	pm := &PartitionManager{config: PartitionConfig{ChunkInterval: 24 * time.Hour}}
+
+	covering := time.Date(2026, 7, 28, 13, 45, 0, 0, time.UTC)
+	require.Equal(t, "relationship_tuple_20260728", pm.chunkTableName(tableTuple, covering))
+
+	// Times within the same interval map to the same chunk.
+	sameDayLater := covering.Add(2 * time.Hour)
+	require.Equal(t, pm.chunkTableName(tableTuple, covering), pm.chunkTableName(tableTuple, sameDayLater))
+
+	nextDay := covering.Add(24 * time.Hour)
+	require.NotEqual(t, pm.chunkTableName(tableTuple, covering), pm.chunkTableName(tableTuple, nextDay))
+}
+
+func TestCurrentIntervalStart(t *testing.T) {
+	pm := &PartitionManager{config: PartitionConfig{ChunkInterval: time.Hour}}
+
+	now := time.Date(2026, 7, 28, 13, 45, 12, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 7, 28, 13, 0, 0, 0, time.UTC), pm.currentIntervalStart(now))
+}
+
+func TestRetentionNanos(t *testing.T) {
+	explicit := &PartitionManager{
+		config:        PartitionConfig{RetentionNanos: int64(time.Hour)},
+		gcWindowNanos: func() int64 { t.Fatal("should not be called when RetentionNanos is set"); return 0 },
+	}
+	require.Equal(t, int64(time.Hour), explicit.retentionNanos())
+
+	derived := &PartitionManager{
+		config:        PartitionConfig{},
+		gcWindowNanos: func() int64 { return int64(24 * time.Hour) },
+	}
+	require.Equal(t, int64(24*time.Hour), derived.retentionNanos())
+}
+
+func TestJoinUnionAll(t *testing.T) {
+	require.Equal(t, "SELECT * FROM `a`", joinUnionAll([]string{"SELECT * FROM `a`"}))
+	require.Equal(
+		t,
+		"SELECT * FROM `a` UNION ALL SELECT * FROM `b` UNION ALL SELECT * FROM `c`",
+		joinUnionAll([]string{"SELECT * FROM `a`", "SELECT * FROM `b`", "SELECT * FROM `c`"}),
+	)
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -25,10 +27,60 @@ type mysqlReader struct {
 	txSource txFactory
 	executor common.QueryExecutor
 	filterer queryFilterer
+
+	// partitions routes relationship reads to the chunk tables overlapping
+	// atRevisionNanos instead of scanning the full history. partitions is
+	// nil when chunk partitioning is disabled; atRevisionNanos is also the
+	// revision reported in audit records.
+	partitions      *PartitionManager
+	atRevisionNanos int64
+
+	// auditSink, when non-nil, receives a common.AuditRecord for every
+	// QueryRelationships, ReverseQueryRelationships and ReadNamespaceByName
+	// call; ListAllNamespaces and LookupNamespacesWithNames are not audited.
+	// Filtering of which operations are recorded is
+	// the sink's responsibility, via the shouldAudit predicate it was
+	// constructed with.
+	auditSink common.AuditSink
 }
 
 type queryFilterer func(original sq.SelectBuilder) sq.SelectBuilder
 
+// sqlizer is implemented by the query builder types returned from
+// common.NewSchemaQueryFilterer, letting the reader resolve the final SQL
+// for an audit record without needing to know the concrete builder type.
+type sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// emitAudit records op against mr.auditSink, if one is configured. SQL
+// resolution failures are folded into the record's own error rather than
+// suppressing the audit entry, since "we couldn't tell what ran" is itself
+// worth recording.
+func (mr *mysqlReader) emitAudit(ctx context.Context, op common.AuditOperation, query sqlizer, opErr error) {
+	if mr.auditSink == nil {
+		return
+	}
+
+	resolvedSQL := ""
+	if query != nil {
+		if sql, args, err := query.ToSql(); err == nil {
+			resolvedSQL = common.InlineSqlArgs(sql, args)
+		} else {
+			opErr = errors.Join(opErr, fmt.Errorf("unable to resolve audited SQL: %w", err))
+		}
+	}
+
+	mr.auditSink.Audit(ctx, common.AuditRecord{
+		Operation: op,
+		Caller:    common.AuditCallerFromContext(ctx),
+		SQL:       resolvedSQL,
+		Revision:  strconv.FormatInt(mr.atRevisionNanos, 10),
+		Err:       opErr,
+		Timestamp: time.Now(),
+	})
+}
+
 const (
 	errUnableToReadConfig     = "unable to read namespace config: %w"
 	errUnableToListNamespaces = "unable to list namespaces: %w"
@@ -51,12 +103,20 @@ func (mr *mysqlReader) QueryRelationships(
 	filter datastore.RelationshipsFilter,
 	opts ...options.QueryOptionsOption,
 ) (iter datastore.RelationshipIterator, err error) {
-	qBuilder, err := common.NewSchemaQueryFilterer(schema, mr.filterer(mr.QueryTuplesQuery)).FilterWithRelationshipsFilter(filter)
+	baseQuery := mr.QueryTuplesQuery
+	if mr.partitions != nil {
+		baseQuery = mr.partitions.RouteToChunks(ctx, baseQuery, mr.atRevisionNanos)
+	}
+
+	qBuilder, err := common.NewSchemaQueryFilterer(schema, mr.filterer(baseQuery)).FilterWithRelationshipsFilter(filter)
 	if err != nil {
+		mr.emitAudit(ctx, common.AuditOperationQueryRelationships, nil, err)
 		return nil, err
 	}
 
-	return mr.executor.ExecuteQuery(ctx, qBuilder, opts...)
+	iter, err = mr.executor.ExecuteQuery(ctx, qBuilder, opts...)
+	mr.emitAudit(ctx, common.AuditOperationQueryRelationships, qBuilder, err)
+	return iter, err
 }
 
 func (mr *mysqlReader) ReverseQueryRelationships(
@@ -64,9 +124,15 @@ func (mr *mysqlReader) ReverseQueryRelationships(
 	subjectsFilter datastore.SubjectsFilter,
 	opts ...options.ReverseQueryOptionsOption,
 ) (iter datastore.RelationshipIterator, err error) {
-	qBuilder, err := common.NewSchemaQueryFilterer(schema, mr.filterer(mr.QueryTuplesQuery)).
+	baseQuery := mr.QueryTuplesQuery
+	if mr.partitions != nil {
+		baseQuery = mr.partitions.RouteToChunks(ctx, baseQuery, mr.atRevisionNanos)
+	}
+
+	qBuilder, err := common.NewSchemaQueryFilterer(schema, mr.filterer(baseQuery)).
 		FilterWithSubjectsSelectors(subjectsFilter.AsSelector())
 	if err != nil {
+		mr.emitAudit(ctx, common.AuditOperationReverseQueryRelationships, nil, err)
 		return nil, err
 	}
 
@@ -78,13 +144,15 @@ func (mr *mysqlReader) ReverseQueryRelationships(
 			FilterToRelation(queryOpts.ResRelation.Relation)
 	}
 
-	return mr.executor.ExecuteQuery(
+	iter, err = mr.executor.ExecuteQuery(
 		ctx,
 		qBuilder,
 		options.WithLimit(queryOpts.LimitForReverse),
 		options.WithAfter(queryOpts.AfterForReverse),
 		options.WithSort(queryOpts.SortForReverse),
 	)
+	mr.emitAudit(ctx, common.AuditOperationReverseQueryRelationships, qBuilder, err)
+	return iter, err
 }
 
 func (mr *mysqlReader) ReadNamespaceByName(ctx context.Context, nsName string) (*core.NamespaceDefinition, datastore.Revision, error) {
@@ -94,7 +162,9 @@ func (mr *mysqlReader) ReadNamespaceByName(ctx context.Context, nsName string) (
 	}
 	defer common.LogOnError(ctx, txCleanup)
 
+	namespaceQuery := mr.filterer(mr.ReadNamespaceQuery).Where(sq.Eq{colNamespace: nsName})
 	loaded, version, err := loadNamespace(ctx, nsName, tx, mr.filterer(mr.ReadNamespaceQuery))
+	mr.emitAudit(ctx, common.AuditOperationReadNamespaceByName, namespaceQuery, err)
 	switch {
 	case errors.As(err, &datastore.ErrNamespaceNotFound{}):
 		return nil, datastore.NoRevision, err
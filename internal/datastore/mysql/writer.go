@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+// mysqlReadWriteTransaction wraps a reader over an in-flight write
+// transaction. txID is minted when the transaction begins (by inserting the
+// new row into relationship_tuple_transaction), so every relationship and
+// namespace write inside the transaction is tagged with it.
+type mysqlReadWriteTransaction struct {
+	*mysqlReader
+
+	tx   *sql.Tx
+	txID uint64
+}
+
+// newMySQLReadWriteTransaction wraps reader, built via newMySQLReader, over
+// an in-flight transaction. Sourcing the reader from the same options
+// pipeline as read-only callers is what lets WithAuditSink apply uniformly
+// to both the read and write paths.
+func newMySQLReadWriteTransaction(reader *mysqlReader, tx *sql.Tx, txID uint64) *mysqlReadWriteTransaction {
+	return &mysqlReadWriteTransaction{mysqlReader: reader, tx: tx, txID: txID}
+}
+
+// commit commits the underlying transaction and emits a single
+// common.AuditOperationWrite record summarizing it. One record per
+// transaction, rather than one per relationship/namespace write it
+// contained, mirrors the transaction's own atomicity: every write inside it
+// lands together, or none do, so there's nothing more for a sink to learn
+// from auditing them individually. The record's SQL is left empty.
+func (rwt *mysqlReadWriteTransaction) commit(ctx context.Context) error {
+	err := rwt.tx.Commit()
+
+	if rwt.auditSink != nil {
+		rwt.auditSink.Audit(ctx, common.AuditRecord{
+			Operation: common.AuditOperationWrite,
+			Caller:    common.AuditCallerFromContext(ctx),
+			Revision:  strconv.FormatUint(rwt.txID, 10),
+			Err:       err,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return err
+}
+
+// revision returns the datastore.Revision produced by this transaction, for
+// callers that need to hand it back once commit succeeds.
+func (rwt *mysqlReadWriteTransaction) revision() datastore.Revision {
+	return revisions.NewForTransactionID(rwt.txID)
+}